@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
+
+	"github.com/iamemilio/dependencyFramework/dependencygraph/internal/stack"
 )
 
 type status struct {
@@ -15,6 +18,7 @@ type Node struct {
 	blocked         bool   // A node is blocked when a dependency fails, making it unable to run
 	hasDependencies bool   // a flag that tracks if a node has any dependencies
 	depsRemaining   int    // a count of the dependencies that the current node is waiting on
+	feed            *Feed  // optional event feed this node publishes progress to
 	sync.Mutex
 	dependencyOf []*Node // a list of pointers to nodes the current node is a dependency of
 	*status              // nil = not run, false = passed, true = failed
@@ -24,6 +28,28 @@ func NewNode(name string) *Node {
 	return &Node{name: name}
 }
 
+// Attach wires the node to feed, so Start/Pass/Fail publish NodeStarted/
+// NodePassed/NodeFailed/NodeBlocked events to it. Call this before any
+// concurrent use of the node; it is not itself safe to call concurrently
+// with Start/Pass/Fail.
+func (m *Node) Attach(feed *Feed) {
+	m.feed = feed
+}
+
+// Start publishes a NodeStarted event if a Feed is attached. Callers that
+// run a node's work (e.g. runner.Scheduler) call this immediately before
+// doing so.
+func (m *Node) Start() {
+	m.publish(NodeStarted)
+}
+
+func (m *Node) publish(t EventType) {
+	if m.feed == nil {
+		return
+	}
+	m.feed.Publish(Event{Type: t, Node: m.name, Time: time.Now()})
+}
+
 func (m *Node) Pass() error {
 	m.Lock()
 	defer m.Unlock()
@@ -37,6 +63,7 @@ func (m *Node) Pass() error {
 		dep.depsRemaining -= 1
 		dep.Unlock()
 	}
+	m.publish(NodePassed)
 	return nil
 }
 
@@ -47,16 +74,15 @@ func (m *Node) Fail() error {
 		return fmt.Errorf("you cannot apply results to a node multiple times")
 	}
 	m.status = &status{Failed: true}
-
-	for _, dep := range m.dependencyOf {
-		dep.Lock()
-		dep.depsRemaining -= 1
-		dep.Unlock()
-	}
+	m.publish(NodeFailed)
 
 	// Mark all dependencies of node as blocked
-	// Walks the whole graph and marks all dependencies affected as blocked
-	nodes := New()
+	// Walks the whole graph and marks all dependencies affected as blocked.
+	// depsRemaining is decremented in the same critical section as the
+	// blocked write so a dependent can never observe depsRemaining reach 0
+	// via a concurrently-passing sibling before it is marked blocked - see
+	// Ready, which reads both fields under the same lock.
+	nodes := stack.New[*Node]()
 	for _, mod := range m.dependencyOf {
 		nodes.Push(mod)
 	}
@@ -71,7 +97,11 @@ func (m *Node) Fail() error {
 		}
 		if !visited[node.name] {
 			visited[node.name] = true
+			node.Lock()
+			node.depsRemaining -= 1
 			node.blocked = true // effectively removes the node from the graph
+			node.publish(NodeBlocked)
+			node.Unlock()
 			for _, dependency := range node.dependencyOf {
 				if !visited[dependency.name] {
 					nodes.Push(dependency)
@@ -89,7 +119,8 @@ func (m *Node) DependsOn(dep *Node) error {
 
 	dep.Lock()
 	for _, d := range dep.dependencyOf {
-		if d.name == dep.name {
+		if d.name == m.name {
+			dep.Unlock()
 			return fmt.Errorf("node dependency %s is a duplicate", dep.name)
 		}
 	}
@@ -120,6 +151,31 @@ func (m *Node) DependsOnList(deps []*Node) error {
 	return nil
 }
 
+// Name returns the node's unique name
+func (m *Node) Name() string {
+	return m.name
+}
+
+// Ready reports whether every one of the node's dependencies has
+// completed, it has not been blocked by an upstream failure, and it has
+// not already run. Callers that drive node execution themselves (e.g.
+// runner.Scheduler) use this to know when a node can be dispatched.
+func (m *Node) Ready() bool {
+	m.Lock()
+	defer m.Unlock()
+	return !m.blocked && m.depsRemaining == 0 && m.status == nil
+}
+
+// Dependents returns the nodes that depend on this one (i.e. the nodes
+// this node is a dependency of).
+func (m *Node) Dependents() []*Node {
+	m.Lock()
+	defer m.Unlock()
+	out := make([]*Node, len(m.dependencyOf))
+	copy(out, m.dependencyOf)
+	return out
+}
+
 // GetDependencyNames returns the names of the nodes the current node
 // is a dependency of as a list of strings
 // For debugging purposes
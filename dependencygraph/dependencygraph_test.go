@@ -0,0 +1,34 @@
+package dependencygraph
+
+import "testing"
+
+func TestDependsOnRejectsDuplicateEdge(t *testing.T) {
+	a := NewNode("a")
+	b := NewNode("b")
+
+	if err := b.DependsOn(a); err != nil {
+		t.Fatalf("first DependsOn call: unexpected error: %v", err)
+	}
+	if err := b.DependsOn(a); err == nil {
+		t.Fatal("second DependsOn call with the same dependency: expected a duplicate error, got nil")
+	}
+	if got := len(a.dependencyOf); got != 1 {
+		t.Fatalf("a.dependencyOf has %d entries, want 1 (duplicate edge must not be added)", got)
+	}
+}
+
+func TestDependsOnRejectsSelfDependency(t *testing.T) {
+	a := NewNode("a")
+	if err := a.DependsOn(a); err == nil {
+		t.Fatal("expected an error when a node depends on itself")
+	}
+}
+
+func TestDependsOnListRejectsDuplicates(t *testing.T) {
+	a := NewNode("a")
+	b := NewNode("b")
+
+	if err := b.DependsOnList([]*Node{a, a}); err == nil {
+		t.Fatal("expected an error for a duplicate entry in the dependency list")
+	}
+}
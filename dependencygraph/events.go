@@ -0,0 +1,107 @@
+package dependencygraph
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType identifies a graph progress event.
+type EventType int
+
+const (
+	// NodeStarted fires when a node begins running its work.
+	NodeStarted EventType = iota
+	// NodePassed fires when a node's Pass is recorded.
+	NodePassed
+	// NodeFailed fires when a node's Fail is recorded.
+	NodeFailed
+	// NodeBlocked fires for every descendant a failure blocks.
+	NodeBlocked
+	// GraphCompleted fires once a whole graph run has finished. Node has
+	// no single owner for "the graph", so callers driving a run (e.g.
+	// runner.Scheduler) publish this themselves via Feed.Publish.
+	GraphCompleted
+)
+
+func (t EventType) String() string {
+	switch t {
+	case NodeStarted:
+		return "NodeStarted"
+	case NodePassed:
+		return "NodePassed"
+	case NodeFailed:
+		return "NodeFailed"
+	case NodeBlocked:
+		return "NodeBlocked"
+	case GraphCompleted:
+		return "GraphCompleted"
+	default:
+		return "Unknown"
+	}
+}
+
+// Event reports a single progress transition.
+type Event struct {
+	Type EventType
+	Node string // empty for GraphCompleted
+	Time time.Time
+}
+
+// subscriberBuffer is how many events a subscriber can be behind before
+// Feed starts dropping events for it.
+const subscriberBuffer = 32
+
+// Feed is a fan-out event bus for graph progress, so external UIs, log
+// aggregators, or metric exporters can observe a run without polling
+// Node status. Attach a Feed to every Node in a graph with Node.Attach to
+// have it publish NodeStarted/NodePassed/NodeFailed/NodeBlocked.
+type Feed struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewFeed creates an empty Feed.
+func NewFeed() *Feed {
+	return &Feed{subscribers: make(map[chan Event]struct{})}
+}
+
+// Subscribe returns a channel that receives every Event published after
+// this call. If a subscriber falls behind (its buffer of subscriberBuffer
+// events fills up), further events are dropped for that subscriber
+// rather than blocking the publisher or other subscribers; there is no
+// replay of dropped events.
+func (f *Feed) Subscribe() <-chan Event {
+	ch := make(chan Event, subscriberBuffer)
+	f.mu.Lock()
+	f.subscribers[ch] = struct{}{}
+	f.mu.Unlock()
+	return ch
+}
+
+// Unsubscribe stops delivery to a channel returned by Subscribe and
+// closes it. Safe to call more than once for the same channel.
+func (f *Feed) Unsubscribe(ch <-chan Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.subscribers {
+		if c == ch {
+			delete(f.subscribers, c)
+			close(c)
+			return
+		}
+	}
+}
+
+// Publish fans e out to every current subscriber, dropping e for any
+// subscriber whose buffer is full instead of blocking.
+func (f *Feed) Publish(e Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subscribers {
+		select {
+		case ch <- e:
+		default:
+			// subscriber is behind; drop rather than block the feed
+		}
+	}
+}
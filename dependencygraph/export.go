@@ -0,0 +1,209 @@
+package dependencygraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// DOTOptions configures WriteDOT.
+type DOTOptions struct {
+	// ClusterByDepth groups nodes into Graphviz subgraphs by their depth
+	// from the graph's roots.
+	ClusterByDepth bool
+}
+
+// DOTOption configures a WriteDOT call.
+type DOTOption func(*DOTOptions)
+
+// WithDepthClusters enables grouping nodes into Graphviz subgraphs by
+// depth from the graph's roots.
+func WithDepthClusters() DOTOption {
+	return func(o *DOTOptions) { o.ClusterByDepth = true }
+}
+
+// WriteDOT writes nodes to w as a Graphviz DOT graph, coloring each node
+// by its status (unrun/passed/failed/blocked). With WithDepthClusters,
+// nodes are also grouped into subgraphs by their depth from the graph's
+// roots.
+func WriteDOT(w io.Writer, nodes []*Node, opts ...DOTOption) error {
+	var cfg DOTOptions
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if _, err := fmt.Fprintln(w, "digraph dependencygraph {"); err != nil {
+		return err
+	}
+
+	if cfg.ClusterByDepth {
+		if err := writeDOTClusters(w, nodes, computeDepths(nodes)); err != nil {
+			return err
+		}
+	} else {
+		for _, n := range nodes {
+			if err := writeDOTNode(w, n); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		for _, dep := range n.dependencyOf {
+			if _, err := fmt.Fprintf(w, "  \"%s\" -> \"%s\";\n", escapeDOTString(n.name), escapeDOTString(dep.name)); err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+func writeDOTClusters(w io.Writer, nodes []*Node, depths map[*Node]int) error {
+	byDepth := map[int][]*Node{}
+	maxDepth := 0
+	for _, n := range nodes {
+		d := depths[n]
+		byDepth[d] = append(byDepth[d], n)
+		if d > maxDepth {
+			maxDepth = d
+		}
+	}
+
+	for d := 0; d <= maxDepth; d++ {
+		if _, err := fmt.Fprintf(w, "  subgraph cluster_depth_%d {\n    label=\"depth %d\";\n", d, d); err != nil {
+			return err
+		}
+		for _, n := range byDepth[d] {
+			if err := writeDOTNode(w, n); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, "  }"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDOTNode(w io.Writer, n *Node) error {
+	name := escapeDOTString(n.name)
+	// label embeds a literal Graphviz "\n" line-break escape, so it must
+	// not be run through another round of escaping (e.g. %q) that would
+	// double the backslash.
+	label := fmt.Sprintf("%s\\n%s", name, nodeStatusLabel(n))
+	_, err := fmt.Fprintf(w, "  \"%s\" [style=filled, fillcolor=%s, label=\"%s\"];\n", name, nodeColor(n), label)
+	return err
+}
+
+// escapeDOTString escapes the characters that would otherwise break out
+// of a DOT quoted string or ID.
+func escapeDOTString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return s
+}
+
+func nodeColor(n *Node) string {
+	switch {
+	case n.blocked:
+		return "gray"
+	case n.status == nil:
+		return "white"
+	case n.status.Failed:
+		return "red"
+	default:
+		return "green"
+	}
+}
+
+func nodeStatusLabel(n *Node) string {
+	switch {
+	case n.blocked:
+		return "blocked"
+	case n.status == nil:
+		return "unrun"
+	case n.status.Failed:
+		return "failed"
+	default:
+		return "passed"
+	}
+}
+
+// computeDepths walks nodes breadth-first from their roots over
+// dependencyOf edges, returning each node's shortest distance from the
+// roots. Unlike Step, this ignores run/blocked state entirely, so it works
+// on a graph at any point in its lifecycle and gives every node - including
+// ones later blocked by a failure - a depth. A node reached by paths of
+// different lengths gets the shortest one, which is less than the depth at
+// which repeated Step calls return it, since Step only returns a node once
+// all of its dependencies have run. O(V+E).
+func computeDepths(nodes []*Node) map[*Node]int {
+	depths := make(map[*Node]int)
+
+	var queue []*Node
+	for _, n := range nodes {
+		if !n.hasDependencies {
+			depths[n] = 0
+			queue = append(queue, n)
+		}
+	}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, dep := range n.dependencyOf {
+			if _, seen := depths[dep]; !seen {
+				depths[dep] = depths[n] + 1
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return depths
+}
+
+// ExportedNode is the JSON representation of a single node for
+// WriteJSON.
+type ExportedNode struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "unrun", "passed", "failed", or "blocked"
+	Depth  int    `json:"depth"`
+}
+
+// ExportedEdge is a single dependency edge, encoded as a (dependency,
+// dependent) name pair.
+type ExportedEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Export is the JSON representation of a graph for visualization
+// tooling: plain node/edge lists, with no pointers to resolve.
+type Export struct {
+	Nodes []ExportedNode `json:"nodes"`
+	Edges []ExportedEdge `json:"edges"`
+}
+
+// WriteJSON writes nodes to w as an Export: each node's status and its
+// depth from the graph's roots, plus its edges as name pairs. This is
+// the JSON counterpart of WriteDOT for tooling that renders its own
+// visualization instead of Graphviz.
+func WriteJSON(w io.Writer, nodes []*Node) error {
+	depths := computeDepths(nodes)
+
+	export := Export{Nodes: make([]ExportedNode, 0, len(nodes))}
+	for _, n := range nodes {
+		export.Nodes = append(export.Nodes, ExportedNode{
+			Name:   n.name,
+			Status: nodeStatusLabel(n),
+			Depth:  depths[n],
+		})
+		for _, dep := range n.dependencyOf {
+			export.Edges = append(export.Edges, ExportedEdge{From: n.name, To: dep.name})
+		}
+	}
+
+	return json.NewEncoder(w).Encode(export)
+}
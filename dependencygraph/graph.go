@@ -0,0 +1,82 @@
+package dependencygraph
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Graph owns a set of uniquely-named nodes, enforcing that uniqueness at
+// construction time rather than leaving it to the caller.
+type Graph struct {
+	mu    sync.Mutex
+	nodes map[string]*Node
+	order []string // insertion order, for Nodes/Edges
+}
+
+// NewGraph creates an empty Graph.
+func NewGraph() *Graph {
+	return &Graph{nodes: make(map[string]*Node)}
+}
+
+// AddNode creates a new node named name and adds it to the graph. name
+// must be unique within the graph.
+func (g *Graph) AddNode(name string) (*Node, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if _, exists := g.nodes[name]; exists {
+		return nil, fmt.Errorf("node %q already exists in this graph", name)
+	}
+
+	n := NewNode(name)
+	g.nodes[name] = n
+	g.order = append(g.order, name)
+	return n, nil
+}
+
+// MustGet returns the node named name, panicking if it is not present.
+// It is intended for wiring up dependencies at graph-construction time,
+// where a missing node is a programmer error.
+func (g *Graph) MustGet(name string) *Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	n, ok := g.nodes[name]
+	if !ok {
+		panic(fmt.Sprintf("dependencygraph: no node named %q", name))
+	}
+	return n
+}
+
+// Nodes returns every node in the graph, in the order they were added.
+func (g *Graph) Nodes() []*Node {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	nodes := make([]*Node, 0, len(g.order))
+	for _, name := range g.order {
+		nodes = append(nodes, g.nodes[name])
+	}
+	return nodes
+}
+
+// Edge is a single dependency edge: From is a dependency of To.
+type Edge struct {
+	From string
+	To   string
+}
+
+// Edges returns every edge in the graph as (dependency, dependent) name
+// pairs, in the order their owning nodes were added.
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var edges []Edge
+	for _, name := range g.order {
+		for _, dep := range g.nodes[name].dependencyOf {
+			edges = append(edges, Edge{From: name, To: dep.name})
+		}
+	}
+	return edges
+}
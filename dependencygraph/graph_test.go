@@ -0,0 +1,85 @@
+package dependencygraph
+
+import "testing"
+
+func TestGraphAddNodeEnforcesUniqueNames(t *testing.T) {
+	g := NewGraph()
+
+	if _, err := g.AddNode("a"); err != nil {
+		t.Fatalf(`AddNode("a"): unexpected error: %v`, err)
+	}
+	if _, err := g.AddNode("a"); err == nil {
+		t.Fatal(`AddNode("a") a second time: expected an error, got nil`)
+	}
+}
+
+func TestGraphMustGetPanicsOnMissingNode(t *testing.T) {
+	g := NewGraph()
+	if _, err := g.AddNode("a"); err != nil {
+		t.Fatalf(`AddNode("a"): unexpected error: %v`, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("MustGet on a missing node: expected a panic, got none")
+		}
+	}()
+	g.MustGet("missing")
+}
+
+func TestGraphEdges(t *testing.T) {
+	g := NewGraph()
+	a, err := g.AddNode("a")
+	if err != nil {
+		t.Fatalf(`AddNode("a"): unexpected error: %v`, err)
+	}
+	b, err := g.AddNode("b")
+	if err != nil {
+		t.Fatalf(`AddNode("b"): unexpected error: %v`, err)
+	}
+	c, err := g.AddNode("c")
+	if err != nil {
+		t.Fatalf(`AddNode("c"): unexpected error: %v`, err)
+	}
+
+	if err := b.DependsOn(a); err != nil {
+		t.Fatalf("b.DependsOn(a): unexpected error: %v", err)
+	}
+	if err := c.DependsOn(a); err != nil {
+		t.Fatalf("c.DependsOn(a): unexpected error: %v", err)
+	}
+
+	edges := g.Edges()
+	want := map[Edge]bool{
+		{From: "a", To: "b"}: true,
+		{From: "a", To: "c"}: true,
+	}
+	if len(edges) != len(want) {
+		t.Fatalf("Edges() returned %d edges, want %d: %v", len(edges), len(want), edges)
+	}
+	for _, e := range edges {
+		if !want[e] {
+			t.Fatalf("Edges() returned unexpected edge %v", e)
+		}
+	}
+}
+
+func TestGraphNodesPreservesInsertionOrder(t *testing.T) {
+	g := NewGraph()
+	for _, name := range []string{"a", "b", "c"} {
+		if _, err := g.AddNode(name); err != nil {
+			t.Fatalf("AddNode(%q): unexpected error: %v", name, err)
+		}
+	}
+
+	nodes := g.Nodes()
+	want := []string{"a", "b", "c"}
+	if len(nodes) != len(want) {
+		t.Fatalf("Nodes() returned %d nodes, want %d", len(nodes), len(want))
+	}
+	for i, n := range nodes {
+		if n.Name() != want[i] {
+			t.Fatalf("Nodes()[%d] = %q, want %q", i, n.Name(), want[i])
+		}
+	}
+}
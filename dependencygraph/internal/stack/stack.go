@@ -0,0 +1,48 @@
+// Package stack provides a small generic LIFO used internally by
+// dependencygraph to walk the DAG without recursion.
+package stack
+
+import (
+	"errors"
+	"sync"
+)
+
+// Stack is a simple thread-safe LIFO stack.
+type Stack[T any] struct {
+	mu sync.Mutex
+	s  []T
+}
+
+// New creates an empty Stack.
+func New[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+func (s *Stack[T]) Push(v T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.s = append(s.s, v)
+}
+
+func (s *Stack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var zero T
+	l := len(s.s)
+	if l == 0 {
+		return zero, errors.New("empty stack")
+	}
+
+	res := s.s[l-1]
+	s.s = s.s[:l-1]
+	return res, nil
+}
+
+func (s *Stack[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.s)
+}
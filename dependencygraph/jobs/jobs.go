@@ -0,0 +1,219 @@
+// Package jobs promotes the ad-hoc jobNode/job pairing that used to live
+// in main into a first-class, reusable building block on top of
+// dependencygraph: a Job interface with retries, timeouts, and typed
+// results, wired into the runner's Scheduler via RunAll.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	graph "github.com/iamemilio/dependencyFramework/dependencygraph"
+	"github.com/iamemilio/dependencyFramework/dependencygraph/runner"
+)
+
+// Result carries the outcome of a single job run: anything the job
+// collected on stdout/stderr, plus any non-fatal validation errors.
+type Result struct {
+	Stdout           []string
+	Stderr           []string
+	ValidationErrors []error
+}
+
+// Job is the work a Task runs for its node. Run may be called more than
+// once if the Task's RetryPolicy allows retries, and must honor ctx
+// cancellation/deadline.
+type Job interface {
+	Run(ctx context.Context) (Result, error)
+}
+
+// BackoffStrategy picks the delay before a retry attempt.
+type BackoffStrategy int
+
+const (
+	// ConstantBackoff waits RetryPolicy.BaseDelay before every retry.
+	ConstantBackoff BackoffStrategy = iota
+	// ExponentialBackoff doubles the delay on each successive retry,
+	// starting from RetryPolicy.BaseDelay.
+	ExponentialBackoff
+	// JitteredBackoff picks a random delay between zero and the
+	// ExponentialBackoff delay for that retry, to avoid thundering-herd
+	// retries across many jobs.
+	JitteredBackoff
+)
+
+// RetryPolicy controls how many times, and how long between, a Task
+// retries a failing Job. The zero value means no retries.
+type RetryPolicy struct {
+	MaxAttempts int // <= 1 means the job is never retried
+	Backoff     BackoffStrategy
+	BaseDelay   time.Duration
+}
+
+// delay returns how long to wait before retryNum (1-indexed).
+func (p RetryPolicy) delay(retryNum int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	exp := p.BaseDelay * time.Duration(int64(1)<<uint(retryNum-1))
+	switch p.Backoff {
+	case ExponentialBackoff:
+		return exp
+	case JitteredBackoff:
+		return time.Duration(rand.Int63n(int64(exp) + 1))
+	default:
+		return p.BaseDelay
+	}
+}
+
+// Task binds a Job to a node in the dependency graph, along with the
+// per-node retry/timeout/soft-fail configuration that governs how
+// failures propagate. A Task satisfies runner.Runnable.
+type Task struct {
+	// Retry is consulted after a failing Job.Run before the node is
+	// marked Fail()ed.
+	Retry RetryPolicy
+	// Timeout bounds each individual attempt. <= 0 means no timeout.
+	Timeout time.Duration
+	// SoftFail, if true, means a Result carrying ValidationErrors does
+	// not fail the node or block its descendants - the errors are still
+	// recorded in Result for inspection.
+	SoftFail bool
+
+	node *graph.Node
+	job  Job
+
+	mu     sync.Mutex
+	result Result
+}
+
+// NewTask creates a Task wrapping job as a node named name. name must be
+// unique, non empty; with this constructor the library cannot enforce
+// uniqueness across tasks on its own - use NewTaskIn for that.
+func NewTask(name string, job Job) (*Task, error) {
+	if name == "" {
+		return nil, fmt.Errorf("a task must have a unique, non empty name")
+	}
+	return &Task{node: graph.NewNode(name), job: job}, nil
+}
+
+// NewTaskIn creates a Task wrapping job as a node named name within g,
+// which rejects the name if it is already taken by another node in the
+// same graph.
+func NewTaskIn(g *graph.Graph, name string, job Job) (*Task, error) {
+	n, err := g.AddNode(name)
+	if err != nil {
+		return nil, err
+	}
+	return &Task{node: n, job: job}, nil
+}
+
+// Node returns the graph.Node backing this task.
+func (t *Task) Node() *graph.Node {
+	return t.node
+}
+
+// DependsOn allows you to specify a list of dependencies for a task. All
+// dependencies must be unique and valid.
+func (t *Task) DependsOn(tasks []*Task) error {
+	nodes := make([]*graph.Node, 0, len(tasks))
+	for _, dep := range tasks {
+		nodes = append(nodes, dep.node)
+	}
+	return t.node.DependsOnList(nodes)
+}
+
+// Result returns the Result from the task's most recent Run.
+func (t *Task) Result() Result {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.result
+}
+
+// Run executes the task's Job, retrying according to Retry and bounding
+// each attempt with Timeout. It only returns an error once retries are
+// exhausted, so the caller (typically a runner.Scheduler) only calls
+// node.Fail() after that point.
+func (t *Task) Run(ctx context.Context) error {
+	attempts := t.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var res Result
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if werr := sleep(ctx, t.Retry.delay(attempt-1)); werr != nil {
+				err = werr
+				break
+			}
+		}
+
+		res, err = t.runOnce(ctx)
+		if err == nil {
+			break
+		}
+	}
+
+	t.mu.Lock()
+	t.result = res
+	t.mu.Unlock()
+
+	return err
+}
+
+func (t *Task) runOnce(ctx context.Context) (Result, error) {
+	runCtx := ctx
+	if t.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, t.Timeout)
+		defer cancel()
+	}
+
+	res, err := t.job.Run(runCtx)
+	if err != nil {
+		return res, err
+	}
+	if !t.SoftFail && len(res.ValidationErrors) > 0 {
+		return res, fmt.Errorf("job reported %d validation error(s)", len(res.ValidationErrors))
+	}
+	return res, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RunAll wires tasks into a runner.Scheduler and executes them
+// concurrently, honoring the dependency graph. It returns each task's
+// final Result keyed by node name, plus the aggregated error the
+// scheduler reports.
+func RunAll(ctx context.Context, tasks []*Task, opts runner.Options) (map[string]Result, error) {
+	runnables := make([]runner.Runnable, 0, len(tasks))
+	for _, t := range tasks {
+		runnables = append(runnables, t)
+	}
+
+	sched := runner.New(opts)
+	_, err := sched.Run(ctx, runnables)
+
+	results := make(map[string]Result, len(tasks))
+	for _, t := range tasks {
+		results[t.Node().Name()] = t.Result()
+	}
+	return results, err
+}
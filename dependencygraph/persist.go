@@ -0,0 +1,114 @@
+package dependencygraph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// nodeSnapshot is the on-disk representation of a Node: its scalar run
+// state plus its outgoing edges encoded by name, so a graph can be
+// serialized without leaking pointers.
+type nodeSnapshot struct {
+	Name            string   `json:"name"`
+	Blocked         bool     `json:"blocked"`
+	HasDependencies bool     `json:"hasDependencies"`
+	DepsRemaining   int      `json:"depsRemaining"`
+	Failed          *bool    `json:"failed,omitempty"` // nil = not run
+	DependencyOf    []string `json:"dependencyOf,omitempty"`
+}
+
+func (m *Node) snapshot() nodeSnapshot {
+	m.Lock()
+	defer m.Unlock()
+
+	snap := nodeSnapshot{
+		Name:            m.name,
+		Blocked:         m.blocked,
+		HasDependencies: m.hasDependencies,
+		DepsRemaining:   m.depsRemaining,
+	}
+	if m.status != nil {
+		failed := m.status.Failed
+		snap.Failed = &failed
+	}
+	for _, dep := range m.dependencyOf {
+		snap.DependencyOf = append(snap.DependencyOf, dep.name)
+	}
+	return snap
+}
+
+// MarshalJSON encodes a Node's run state and its edges (by dependent
+// name), so a partially-run graph can be snapshotted to disk. A single
+// node's edges reference other nodes only by name, so reconstructing
+// pointer identity across a whole graph requires Load rather than
+// unmarshaling a Node on its own.
+func (m *Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.snapshot())
+}
+
+// Save writes nodes to w as a JSON array, preserving each node's status,
+// blocked state, depsRemaining count, and edges. Pair with Load to
+// snapshot a partially-run DAG and resume it later, e.g. for CI pipeline
+// crash recovery.
+func Save(w io.Writer, nodes []*Node) error {
+	snapshots := make([]nodeSnapshot, 0, len(nodes))
+	for _, n := range nodes {
+		snapshots = append(snapshots, n.snapshot())
+	}
+	return json.NewEncoder(w).Encode(snapshots)
+}
+
+// Load reads a JSON array written by Save and reconstructs the graph,
+// resolving edges back into *Node pointers by name. It rejects snapshots
+// with duplicate or missing names, edges to unknown nodes, and edges
+// that form a cycle.
+func Load(r io.Reader) ([]*Node, error) {
+	var snapshots []nodeSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshots); err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*Node, len(snapshots))
+	nodes := make([]*Node, 0, len(snapshots))
+	for _, snap := range snapshots {
+		if snap.Name == "" {
+			return nil, fmt.Errorf("snapshot contains a node with an empty name")
+		}
+		if _, exists := byName[snap.Name]; exists {
+			return nil, fmt.Errorf("snapshot contains duplicate node %q", snap.Name)
+		}
+
+		n := &Node{
+			name:            snap.Name,
+			blocked:         snap.Blocked,
+			hasDependencies: snap.HasDependencies,
+			depsRemaining:   snap.DepsRemaining,
+		}
+		if snap.Failed != nil {
+			n.status = &status{Failed: *snap.Failed}
+		}
+		byName[snap.Name] = n
+		nodes = append(nodes, n)
+	}
+
+	for i, snap := range snapshots {
+		n := nodes[i]
+		for _, depName := range snap.DependencyOf {
+			if depName == n.name {
+				return nil, fmt.Errorf("node %q is a dependency of itself", n.name)
+			}
+			dep, ok := byName[depName]
+			if !ok {
+				return nil, fmt.Errorf("node %q is a dependency of unknown node %q", n.name, depName)
+			}
+			n.dependencyOf = append(n.dependencyOf, dep)
+		}
+	}
+
+	if cycles := findCycles(nodes); len(cycles) > 0 {
+		return nil, fmt.Errorf("snapshot has a circular dependency")
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,209 @@
+// Package runner executes a dependencygraph DAG concurrently. It turns the
+// manual Step/Pass/Fail traversal loop into a bounded worker pool that
+// dispatches ready nodes, re-evaluates dependents as work completes, and
+// skips anything downstream of a failure.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	graph "github.com/iamemilio/dependencyFramework/dependencygraph"
+)
+
+// Runnable pairs a node in the dependency graph with the work that should
+// execute for it. Implementations typically wrap a job/task type alongside
+// the graph.Node it represents.
+type Runnable interface {
+	Node() *graph.Node
+	Run(ctx context.Context) error
+}
+
+// Event identifies a node lifecycle transition reported to a Hook.
+type Event int
+
+const (
+	// NodeStarted fires immediately before a node's Runnable is run.
+	NodeStarted Event = iota
+	// NodeFinished fires after a node's Runnable has returned.
+	NodeFinished
+)
+
+// Hook is invoked by the Scheduler as it starts and finishes running each
+// node. It may be nil.
+type Hook func(event Event, name string)
+
+// Options configures a Scheduler.
+type Options struct {
+	// MaxParallelism bounds the number of nodes run concurrently. A value
+	// <= 0 means unbounded (limited only by the number of ready nodes).
+	MaxParallelism int
+	// Hook, if set, is called for every node start/finish event.
+	Hook Hook
+	// Feed, if set, receives a GraphCompleted event once Run returns.
+	Feed *graph.Feed
+}
+
+// Result is the outcome of running a single node.
+type Result struct {
+	// Err is the error returned by the node's Runnable, if any.
+	Err error
+	// Skipped is true if the node was never run because a dependency
+	// failed or the scheduler's context was cancelled first.
+	Skipped bool
+}
+
+// Scheduler runs a set of Runnables to completion, honoring the DAG:
+// ready nodes are dispatched to a bounded worker pool, a node's dependents
+// become ready once it passes, and a failed node blocks all of its
+// descendants.
+type Scheduler struct {
+	opts Options
+}
+
+// New creates a Scheduler configured with opts.
+func New(opts Options) *Scheduler {
+	return &Scheduler{opts: opts}
+}
+
+// Run executes runnables to completion, respecting ctx cancellation. A
+// node is dispatched as soon as it becomes Ready - which a completing
+// parent re-checks for each of its Dependents - so each node runs
+// exactly once even when it has several satisfied parents (a diamond
+// A->B, A->C, B->D, C->D dispatches D once, not twice). It returns a
+// per-node Result keyed by node name - including a Skipped entry for
+// every node that never ran because it was blocked by an upstream
+// failure or ctx was cancelled first - and an aggregated error if ctx
+// was cancelled or any node failed.
+func (s *Scheduler) Run(ctx context.Context, runnables []Runnable) (map[string]Result, error) {
+	byNode := make(map[*graph.Node]Runnable, len(runnables))
+	nodes := make([]*graph.Node, 0, len(runnables))
+	for _, r := range runnables {
+		byNode[r.Node()] = r
+		nodes = append(nodes, r.Node())
+	}
+
+	sem := make(chan struct{}, s.parallelism(len(nodes)))
+
+	results := make(map[string]Result, len(nodes))
+	var resultsMu sync.Mutex
+
+	var wg sync.WaitGroup
+	var dispatchMu sync.Mutex
+	dispatched := make(map[*graph.Node]bool, len(nodes))
+
+	var dispatch func(node *graph.Node)
+	dispatch = func(node *graph.Node) {
+		dispatchMu.Lock()
+		if dispatched[node] || !node.Ready() {
+			dispatchMu.Unlock()
+			return
+		}
+		dispatched[node] = true
+		dispatchMu.Unlock()
+
+		r, ok := byNode[node]
+		if !ok {
+			// Reached via an edge into a node outside the runnable set.
+			// Nothing is run or recorded for it and it is never Passed,
+			// so a dependent that counts it in depsRemaining is not
+			// Ready here and, if it is in the runnable set, ends up
+			// with a Skipped result.
+			for _, dep := range node.Dependents() {
+				dispatch(dep)
+			}
+			return
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			s.runOne(ctx, node, r, &resultsMu, results)
+			// Release our slot before dispatching dependents: a
+			// dependent's dispatch can itself block on sem, and with a
+			// small MaxParallelism this goroutine may be the only one
+			// that can free a slot.
+			<-sem
+			for _, dep := range node.Dependents() {
+				dispatch(dep)
+			}
+		}()
+	}
+
+	for _, root := range graph.GetRootDependencies(nodes) {
+		dispatch(root)
+	}
+	wg.Wait()
+
+	resultsMu.Lock()
+	for _, node := range nodes {
+		if _, ok := results[node.Name()]; !ok {
+			// Never dispatched: blocked by an upstream failure, or ctx
+			// was cancelled before it became Ready.
+			results[node.Name()] = Result{Err: ctx.Err(), Skipped: true}
+		}
+	}
+	resultsMu.Unlock()
+
+	if s.opts.Feed != nil {
+		s.opts.Feed.Publish(graph.Event{Type: graph.GraphCompleted, Time: time.Now()})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	for name, res := range results {
+		if res.Err != nil && !res.Skipped {
+			return results, fmt.Errorf("one or more nodes failed, including %s: %w", name, res.Err)
+		}
+	}
+	return results, nil
+}
+
+func (s *Scheduler) runOne(ctx context.Context, node *graph.Node, r Runnable, mu *sync.Mutex, results map[string]Result) {
+	name := node.Name()
+
+	if err := ctx.Err(); err != nil {
+		mu.Lock()
+		results[name] = Result{Err: err, Skipped: true}
+		mu.Unlock()
+		return
+	}
+
+	s.emit(NodeStarted, name)
+	node.Start()
+	err := r.Run(ctx)
+	s.emit(NodeFinished, name)
+
+	if err != nil {
+		if failErr := node.Fail(); failErr != nil {
+			err = failErr
+		}
+	} else if passErr := node.Pass(); passErr != nil {
+		err = passErr
+	}
+
+	mu.Lock()
+	results[name] = Result{Err: err}
+	mu.Unlock()
+}
+
+func (s *Scheduler) emit(event Event, name string) {
+	if s.opts.Hook != nil {
+		s.opts.Hook(event, name)
+	}
+}
+
+func (s *Scheduler) parallelism(numNodes int) int {
+	if s.opts.MaxParallelism > 0 {
+		return s.opts.MaxParallelism
+	}
+	if numNodes <= 0 {
+		return 1
+	}
+	return numNodes
+}
@@ -0,0 +1,144 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	graph "github.com/iamemilio/dependencyFramework/dependencygraph"
+)
+
+type fakeRunnable struct {
+	node *graph.Node
+	fn   func(ctx context.Context) error
+	runs int32
+}
+
+func (f *fakeRunnable) Node() *graph.Node { return f.node }
+
+func (f *fakeRunnable) Run(ctx context.Context) error {
+	atomic.AddInt32(&f.runs, 1)
+	if f.fn != nil {
+		return f.fn(ctx)
+	}
+	return nil
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestSchedulerRunsReconvergingDiamondOnce covers A->B, A->C, B->D, C->D:
+// D has two satisfied parents once B and C finish, and must still run
+// exactly once.
+func TestSchedulerRunsReconvergingDiamondOnce(t *testing.T) {
+	a := graph.NewNode("a")
+	b := graph.NewNode("b")
+	c := graph.NewNode("c")
+	d := graph.NewNode("d")
+	must(t, b.DependsOn(a))
+	must(t, c.DependsOn(a))
+	must(t, d.DependsOnList([]*graph.Node{b, c}))
+
+	runnables := map[string]*fakeRunnable{
+		"a": {node: a},
+		"b": {node: b},
+		"c": {node: c},
+		"d": {node: d},
+	}
+	list := make([]Runnable, 0, len(runnables))
+	for _, r := range runnables {
+		list = append(list, r)
+	}
+
+	sched := New(Options{MaxParallelism: 1})
+	results, err := sched.Run(context.Background(), list)
+	if err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+
+	for name, r := range runnables {
+		if got := atomic.LoadInt32(&r.runs); got != 1 {
+			t.Errorf("node %q ran %d times, want exactly 1", name, got)
+		}
+		if res := results[name]; res.Err != nil || res.Skipped {
+			t.Errorf("node %q result = %+v, want a clean pass", name, res)
+		}
+	}
+}
+
+// TestSchedulerRecordsSkippedResultsForBlockedNodes covers A->B->C with A
+// failing: B and C are blocked and must still get a Result entry.
+func TestSchedulerRecordsSkippedResultsForBlockedNodes(t *testing.T) {
+	a := graph.NewNode("a")
+	b := graph.NewNode("b")
+	c := graph.NewNode("c")
+	must(t, b.DependsOn(a))
+	must(t, c.DependsOn(b))
+
+	failErr := errors.New("boom")
+	list := []Runnable{
+		&fakeRunnable{node: a, fn: func(ctx context.Context) error { return failErr }},
+		&fakeRunnable{node: b},
+		&fakeRunnable{node: c},
+	}
+
+	sched := New(Options{})
+	results, err := sched.Run(context.Background(), list)
+	if err == nil {
+		t.Fatal("Run: expected an aggregated error from the failing node, got nil")
+	}
+
+	if res, ok := results["a"]; !ok || res.Err != failErr || res.Skipped {
+		t.Errorf(`results["a"] = %+v (ok=%v), want Err=%v Skipped=false`, res, ok, failErr)
+	}
+	for _, name := range []string{"b", "c"} {
+		res, ok := results[name]
+		if !ok {
+			t.Fatalf("results[%q] is missing; every node must have an entry", name)
+		}
+		if !res.Skipped {
+			t.Errorf("results[%q].Skipped = false, want true (blocked by a's failure)", name)
+		}
+	}
+}
+
+// TestSchedulerBlocksSharedChildEvenWhenAnotherParentPassesConcurrently
+// covers A,X -> B with A failing and X passing at the same time: B must
+// never run, regardless of which of A.Fail's blocked-marking or X.Pass's
+// depsRemaining decrement reaches B first. Run with -race: it catches an
+// unsynchronized `node.blocked = true` write racing with Node.Ready's
+// locked read.
+func TestSchedulerBlocksSharedChildEvenWhenAnotherParentPassesConcurrently(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		a := graph.NewNode("a")
+		x := graph.NewNode("x")
+		b := graph.NewNode("b")
+		must(t, b.DependsOnList([]*graph.Node{a, x}))
+
+		failErr := errors.New("boom")
+		bRunnable := &fakeRunnable{node: b}
+		list := []Runnable{
+			&fakeRunnable{node: a, fn: func(ctx context.Context) error { return failErr }},
+			&fakeRunnable{node: x},
+			bRunnable,
+		}
+
+		sched := New(Options{})
+		results, err := sched.Run(context.Background(), list)
+		if err == nil {
+			t.Fatalf("iteration %d: Run: expected an aggregated error from the failing node, got nil", i)
+		}
+
+		if got := atomic.LoadInt32(&bRunnable.runs); got != 0 {
+			t.Fatalf("iteration %d: b ran %d times, want 0 (blocked by a's failure)", i, got)
+		}
+		if res, ok := results["b"]; !ok || !res.Skipped {
+			t.Fatalf("iteration %d: results[\"b\"] = %+v (ok=%v), want Skipped=true", i, res, ok)
+		}
+	}
+}
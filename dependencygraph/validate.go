@@ -0,0 +1,221 @@
+package dependencygraph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateError reports every structural problem Validate found in a
+// graph. A nil *ValidateError (returned as a plain nil error) means the
+// graph is valid.
+type ValidateError struct {
+	// Cycles lists every strongly connected component of size > 1, each
+	// one a set of nodes that depend on each other in a loop.
+	Cycles [][]*Node
+	// Unreachable lists nodes that cannot be reached from the root passed
+	// to Validate. Nil unless a root was given.
+	Unreachable []*Node
+	// MissingRoot is true if a root was given but is not present in the
+	// node set passed to Validate.
+	MissingRoot bool
+}
+
+func (e *ValidateError) Error() string {
+	var parts []string
+	if e.MissingRoot {
+		parts = append(parts, "root node is not present in the given node set")
+	}
+	if len(e.Cycles) > 0 {
+		parts = append(parts, fmt.Sprintf("%d cycle(s) detected", len(e.Cycles)))
+	}
+	if len(e.Unreachable) > 0 {
+		parts = append(parts, fmt.Sprintf("%d node(s) unreachable from root", len(e.Unreachable)))
+	}
+	return fmt.Sprintf("invalid graph: %s", strings.Join(parts, "; "))
+}
+
+// Validate checks nodes for cycles and, if root is non-nil, for nodes
+// that are not reachable from root. Run it before any Pass/Fail call on
+// the graph: once execution starts, a cycle can otherwise only be
+// discovered accidentally, mid-traversal, inside Fail. It returns a
+// *ValidateError describing every problem found, or nil if the graph is
+// valid.
+func Validate(nodes []*Node, root *Node) error {
+	verr := &ValidateError{
+		Cycles: findCycles(nodes),
+	}
+
+	if root != nil {
+		present := false
+		for _, n := range nodes {
+			if n == root {
+				present = true
+				break
+			}
+		}
+		if !present {
+			verr.MissingRoot = true
+		} else {
+			verr.Unreachable = findUnreachable(nodes, root)
+		}
+	}
+
+	if len(verr.Cycles) == 0 && len(verr.Unreachable) == 0 && !verr.MissingRoot {
+		return nil
+	}
+	return verr
+}
+
+// findUnreachable returns the nodes in nodes that cannot be reached from
+// root by following dependencyOf edges.
+func findUnreachable(nodes []*Node, root *Node) []*Node {
+	reachable := map[*Node]bool{root: true}
+	queue := []*Node{root}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		for _, dep := range n.dependencyOf {
+			if !reachable[dep] {
+				reachable[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	var unreachable []*Node
+	for _, n := range nodes {
+		if n != root && !reachable[n] {
+			unreachable = append(unreachable, n)
+		}
+	}
+	return unreachable
+}
+
+// findCycles runs Tarjan's strongly connected components algorithm over
+// nodes using dependencyOf edges, and returns every component of size > 1
+// (a single node can't form a cycle, since DependsOn rejects self-edges).
+// O(V+E).
+func findCycles(nodes []*Node) [][]*Node {
+	st := &tarjanState{
+		index:   make(map[*Node]int),
+		lowlink: make(map[*Node]int),
+		onStack: make(map[*Node]bool),
+	}
+	for _, n := range nodes {
+		if _, ok := st.index[n]; !ok {
+			st.strongConnect(n)
+		}
+	}
+
+	var cycles [][]*Node
+	for _, scc := range st.sccs {
+		if len(scc) > 1 {
+			cycles = append(cycles, scc)
+		}
+	}
+	return cycles
+}
+
+type tarjanState struct {
+	index   map[*Node]int
+	lowlink map[*Node]int
+	onStack map[*Node]bool
+	stack   []*Node
+	counter int
+	sccs    [][]*Node
+}
+
+func (st *tarjanState) strongConnect(v *Node) {
+	st.index[v] = st.counter
+	st.lowlink[v] = st.counter
+	st.counter++
+	st.stack = append(st.stack, v)
+	st.onStack[v] = true
+
+	for _, w := range v.dependencyOf {
+		if _, ok := st.index[w]; !ok {
+			st.strongConnect(w)
+			if st.lowlink[w] < st.lowlink[v] {
+				st.lowlink[v] = st.lowlink[w]
+			}
+		} else if st.onStack[w] {
+			if st.index[w] < st.lowlink[v] {
+				st.lowlink[v] = st.index[w]
+			}
+		}
+	}
+
+	if st.lowlink[v] == st.index[v] {
+		var scc []*Node
+		for {
+			n := st.stack[len(st.stack)-1]
+			st.stack = st.stack[:len(st.stack)-1]
+			st.onStack[n] = false
+			scc = append(scc, n)
+			if n == v {
+				break
+			}
+		}
+		st.sccs = append(st.sccs, scc)
+	}
+}
+
+// TransitiveReduction removes redundant edges from nodes while preserving
+// reachability: if v depends indirectly on u through some other path (for
+// example u->w->v), any direct u->v edge is dropped. Run it only before
+// any Pass/Fail call, since it adjusts depsRemaining directly. For each
+// node it walks the graph once per pair of its direct dependents, so it
+// is O(d*E*(V+E)), where d is the largest number of direct dependents of
+// any node.
+func TransitiveReduction(nodes []*Node) {
+	for _, u := range nodes {
+		redundant := make(map[*Node]bool)
+		for _, v := range u.dependencyOf {
+			for _, w := range u.dependencyOf {
+				if w == v {
+					continue
+				}
+				if reachableFrom(w, v) {
+					redundant[v] = true
+					break
+				}
+			}
+		}
+		if len(redundant) == 0 {
+			continue
+		}
+
+		kept := u.dependencyOf[:0:0]
+		for _, v := range u.dependencyOf {
+			if redundant[v] {
+				v.Lock()
+				v.depsRemaining--
+				v.Unlock()
+				continue
+			}
+			kept = append(kept, v)
+		}
+		u.dependencyOf = kept
+	}
+}
+
+// reachableFrom reports whether target is reachable from start by
+// following one or more dependencyOf edges.
+func reachableFrom(start, target *Node) bool {
+	visited := map[*Node]bool{start: true}
+	stack := []*Node{start}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, next := range n.dependencyOf {
+			if next == target {
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+	return false
+}